@@ -0,0 +1,68 @@
+package config
+
+import "strings"
+
+// Config holds user-configurable behavior for a single tflint run.
+type Config struct {
+	IgnoreModule map[string]bool
+	IgnoreRule   map[string]bool
+	VarFiles     []string
+	Vars         map[string]string
+	Rules        map[string]*Rule
+	Format       string
+}
+
+// Init returns a Config with empty ignore lists and the default formatter.
+func Init() *Config {
+	return &Config{
+		IgnoreModule: map[string]bool{},
+		IgnoreRule:   map[string]bool{},
+		VarFiles:     []string{},
+		Vars:         map[string]string{},
+		Rules:        map[string]*Rule{},
+		Format:       "default",
+	}
+}
+
+// SetFormat records the `--format` flag, selecting which formatter.Formatter
+// renders the final issue list.
+func (c *Config) SetFormat(format string) {
+	c.Format = format
+}
+
+// SetIgnoreModule parses a comma-separated list of module sources to skip.
+func (c *Config) SetIgnoreModule(s string) {
+	c.IgnoreModule = toSet(s)
+}
+
+// SetIgnoreRule parses a comma-separated list of rule names to skip.
+func (c *Config) SetIgnoreRule(s string) {
+	c.IgnoreRule = toSet(s)
+}
+
+// SetVarFiles records `-var-file` paths, evaluated in the order given.
+func (c *Config) SetVarFiles(files []string) {
+	c.VarFiles = files
+}
+
+// SetVars records `-var` overrides given as `key=value` strings.
+func (c *Config) SetVars(vars []string) {
+	c.Vars = map[string]string{}
+	for _, v := range vars {
+		pair := strings.SplitN(v, "=", 2)
+		if len(pair) == 2 {
+			c.Vars[pair[0]] = pair[1]
+		}
+	}
+}
+
+func toSet(s string) map[string]bool {
+	set := map[string]bool{}
+	if s == "" {
+		return set
+	}
+	for _, v := range strings.Split(s, ",") {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"io/ioutil"
+
+	"github.com/hashicorp/hcl"
+)
+
+// Rule is a single `rule "name" { ... }` stanza from `.tflint.hcl`, letting
+// users enable/disable a rule, override its severity, or point the name at
+// an external rule plugin, all without forking tflint.
+type Rule struct {
+	Name     string `hcl:",key"`
+	Enabled  bool
+	Severity string
+	Plugin   string
+}
+
+// rawRule mirrors Rule, except `enabled` decodes as a tri-state pointer so
+// LoadFile can tell an omitted `enabled` attribute (rule stays enabled;
+// users should be able to write a `rule` block that only overrides
+// `severity` or `plugin`) apart from an explicit `enabled = false`.
+type rawRule struct {
+	Name     string `hcl:",key"`
+	Enabled  *bool
+	Severity string
+	Plugin   string
+}
+
+// LoadFile reads a `.tflint.hcl` configuration file and merges its `rule`
+// blocks into c, overwriting any existing entry with the same name. A rule
+// block is enabled unless it explicitly sets `enabled = false`.
+func LoadFile(c *Config, path string) (*Config, error) {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return c, err
+	}
+
+	var raw struct {
+		Rules []*rawRule `hcl:"rule"`
+	}
+	if err := hcl.Unmarshal(src, &raw); err != nil {
+		return c, err
+	}
+
+	for _, rule := range raw.Rules {
+		enabled := true
+		if rule.Enabled != nil {
+			enabled = *rule.Enabled
+		}
+		c.Rules[rule.Name] = &Rule{
+			Name:     rule.Name,
+			Enabled:  enabled,
+			Severity: rule.Severity,
+			Plugin:   rule.Plugin,
+		}
+	}
+	return c, nil
+}
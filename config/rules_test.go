@@ -0,0 +1,85 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestLoadFile(t *testing.T) {
+	cases := []struct {
+		Name    string
+		File    string
+		Rule    string
+		Enabled bool
+		Plugin  string
+	}{
+		{
+			Name: "enabled rule",
+			File: `
+rule "aws_instance_invalid_type" {
+    enabled  = true
+    severity = "warning"
+}`,
+			Rule:    "aws_instance_invalid_type",
+			Enabled: true,
+		},
+		{
+			Name: "disabled rule",
+			File: `
+rule "aws_instance_invalid_type" {
+    enabled = false
+}`,
+			Rule:    "aws_instance_invalid_type",
+			Enabled: false,
+		},
+		{
+			Name: "enabled omitted defaults to true",
+			File: `
+rule "aws_instance_invalid_type" {
+    severity = "warning"
+}`,
+			Rule:    "aws_instance_invalid_type",
+			Enabled: true,
+		},
+		{
+			Name: "external plugin rule",
+			File: `
+rule "my_company_naming_convention" {
+    enabled = true
+    plugin  = "./my_company_naming_convention.so"
+}`,
+			Rule:    "my_company_naming_convention",
+			Enabled: true,
+			Plugin:  "./my_company_naming_convention.so",
+		},
+	}
+
+	for _, tc := range cases {
+		f, err := ioutil.TempFile("", "tflint")
+		if err != nil {
+			t.Fatalf("Failed to create temp file: %s", err)
+		}
+		defer os.Remove(f.Name())
+		f.WriteString(tc.File)
+		f.Close()
+
+		c, err := LoadFile(Init(), f.Name())
+		if err != nil {
+			t.Fatalf("should not be happen error.\nError: %s\n\ntestcase: %s", err, tc.Name)
+			continue
+		}
+
+		rule, ok := c.Rules[tc.Rule]
+		if !ok {
+			t.Fatalf("Bad: rule `%s` was not loaded\n\ntestcase: %s", tc.Rule, tc.Name)
+			continue
+		}
+		if rule.Enabled != tc.Enabled {
+			t.Fatalf("Bad: %t\nExpected: %t\n\ntestcase: %s", rule.Enabled, tc.Enabled, tc.Name)
+		}
+		if rule.Plugin != tc.Plugin {
+			t.Fatalf("Bad: %s\nExpected: %s\n\ntestcase: %s", rule.Plugin, tc.Plugin, tc.Name)
+		}
+	}
+}
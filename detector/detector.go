@@ -0,0 +1,267 @@
+package detector
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/hcl/ast"
+	hclparser "github.com/hashicorp/hcl/hcl/parser"
+	"github.com/hashicorp/hcl/hcl/token"
+	jsonparser "github.com/hashicorp/hcl/json/parser"
+	"github.com/hashicorp/hil"
+	"github.com/wata727/tflint/config"
+	"github.com/wata727/tflint/evaluator"
+	"github.com/wata727/tflint/issue"
+	"github.com/wata727/tflint/logger"
+)
+
+// Detector walks a parsed Terraform template (and any modules it references,
+// local or fetched through moduleResolver) and runs every enabled rule
+// against it.
+type Detector struct {
+	ListMap    map[string]*ast.ObjectList
+	Config     *config.Config
+	EvalConfig *evaluator.Evaluator
+	Logger     *logger.Logger
+}
+
+// Detect runs all enabled rules over d.ListMap and recurses into any
+// modules it finds (resolved through moduleResolver), returning every issue
+// found.
+func (d *Detector) Detect() []*issue.Issue {
+	issues := []*issue.Issue{}
+
+	for name, rule := range registry.Rules() {
+		if d.Config.IgnoreRule[name] {
+			continue
+		}
+		if override, ok := d.Config.Rules[name]; ok && !override.Enabled {
+			continue
+		}
+
+		before := len(issues)
+		rule.Detect(d, &issues)
+		severity := rule.Severity()
+		if override, ok := d.Config.Rules[name]; ok && override.Severity != "" {
+			severity = override.Severity
+		}
+		for _, i := range issues[before:] {
+			i.Severity = severity
+			i.Link = rule.Link()
+		}
+	}
+
+	for _, list := range d.ListMap {
+		for _, item := range list.Filter("module").Items {
+			issues = append(issues, d.detectModule(item)...)
+		}
+	}
+
+	return issues
+}
+
+// DetectFiles re-parses only the given paths into d.ListMap, leaving every
+// other already-loaded file untouched, and then runs Detect() again. This
+// lets a caller that knows which files just changed (e.g. a --watch loop)
+// react to them without reading the files that haven't moved from disk
+// again. The evaluator is still rebuilt from the whole updated ListMap, and
+// Detect() runs every rule over every file, not only the changed ones.
+func (d *Detector) DetectFiles(paths []string) []*issue.Issue {
+	for _, path := range paths {
+		list, err := loadFile(path)
+		if err != nil {
+			d.Logger.Error(err)
+			continue
+		}
+		d.ListMap[filepath.Base(path)] = list
+	}
+
+	evalConfig, err := evaluator.NewEvaluator(d.ListMap, d.Config)
+	if err != nil {
+		d.Logger.Error(err)
+		return []*issue.Issue{}
+	}
+	d.EvalConfig = evalConfig
+
+	return d.Detect()
+}
+
+func (d *Detector) detectModule(item *ast.ObjectItem) []*issue.Issue {
+	sourceToken, err := hclLiteralToken(item, "source")
+	if err != nil {
+		d.Logger.Error(err)
+		return []*issue.Issue{}
+	}
+	source, err := sourceToken.Value()
+	if err != nil {
+		d.Logger.Error(err)
+		return []*issue.Issue{}
+	}
+	src := source.(string)
+
+	if d.Config.IgnoreModule[src] {
+		return []*issue.Issue{}
+	}
+
+	version := ""
+	if versionToken, err := hclLiteralToken(item, "version"); err == nil {
+		if v, err := versionToken.Value(); err == nil {
+			version = v.(string)
+		}
+	}
+
+	dir, err := moduleResolver.Resolve(src, version)
+	if err != nil {
+		d.Logger.Error(err)
+		return []*issue.Issue{}
+	}
+
+	listMap, err := MakeModuleListMap(dir)
+	if err != nil {
+		d.Logger.Error(err)
+		return []*issue.Issue{}
+	}
+
+	evalConfig, err := evaluator.NewEvaluator(listMap, d.Config)
+	if err != nil {
+		d.Logger.Error(err)
+		return []*issue.Issue{}
+	}
+
+	nested := &Detector{
+		ListMap:    listMap,
+		Config:     d.Config,
+		EvalConfig: evalConfig,
+		Logger:     d.Logger,
+	}
+	return nested.Detect()
+}
+
+// MakeModuleListMap parses every *.tf and *.tf.json file directly under dir
+// into the same ListMap shape the top-level detector works with.
+func MakeModuleListMap(dir string) (map[string]*ast.ObjectList, error) {
+	listMap := map[string]*ast.ObjectList{}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range files {
+		if f.IsDir() || !isTerraformFile(f.Name()) {
+			continue
+		}
+
+		list, err := loadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		listMap[f.Name()] = list
+	}
+
+	return listMap, nil
+}
+
+func isTerraformFile(name string) bool {
+	return strings.HasSuffix(name, ".tf") || strings.HasSuffix(name, ".tf.json")
+}
+
+// loadFile parses a single Terraform file, dispatching to the JSON parser
+// for `.tf.json` sources and the native HCL parser for everything else.
+func loadFile(path string) (*ast.ObjectList, error) {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var root *ast.File
+	if strings.HasSuffix(path, ".tf.json") {
+		root, err = jsonparser.Parse(src)
+	} else {
+		root, err = hclparser.Parse(src)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	list, ok := root.Node.(*ast.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("`%s` is not a valid list of HCL objects", path)
+	}
+	return list, nil
+}
+
+// keyText returns an object key's text, stripping the surrounding quotes
+// that the JSON parser always produces (JSON object keys are always quoted
+// strings, even when the equivalent HCL key would be a bare identifier).
+func keyText(key *ast.ObjectKey) string {
+	if key.Token.JSON {
+		return strings.Trim(key.Token.Text, `"`)
+	}
+	return key.Token.Text
+}
+
+// hclLiteralToken returns the literal token assigned to key within item,
+// erroring when key is missing or its value isn't a literal.
+func hclLiteralToken(item *ast.ObjectItem, key string) (token.Token, error) {
+	if object, ok := item.Val.(*ast.ObjectType); ok {
+		for _, item := range object.List.Items {
+			if keyText(item.Keys[0]) != key {
+				continue
+			}
+			if lit, ok := item.Val.(*ast.LiteralType); ok {
+				return lit.Token, nil
+			}
+			return token.Token{}, fmt.Errorf("`%s` value is not a literal", key)
+		}
+	}
+
+	return token.Token{}, fmt.Errorf("key `%s` not found", key)
+}
+
+// hclObjectItems returns the object items assigned to key within item,
+// erroring when key is missing.
+func hclObjectItems(item *ast.ObjectItem, key string) ([]*ast.ObjectItem, error) {
+	items := []*ast.ObjectItem{}
+
+	// The JSON parser flattens an object whose members are all objects into
+	// one item with a longer key list, so `"web": {"root_block_device": {...}}`
+	// arrives as a single item keyed [web, root_block_device].
+	if len(item.Keys) > 1 && keyText(item.Keys[1]) == key {
+		items = append(items, &ast.ObjectItem{Keys: item.Keys[1:], Val: item.Val})
+	}
+
+	if object, ok := item.Val.(*ast.ObjectType); ok {
+		for _, item := range object.List.Items {
+			if keyText(item.Keys[0]) == key {
+				items = append(items, item)
+			}
+		}
+	}
+
+	if len(items) == 0 {
+		return items, fmt.Errorf("key `%s` not found", key)
+	}
+	return items, nil
+}
+
+// IsKeyNotFound reports whether key has no literal value set on item.
+func IsKeyNotFound(item *ast.ObjectItem, key string) bool {
+	_, err := hclLiteralToken(item, key)
+	return err != nil
+}
+
+// evalToString evaluates a HIL source string and requires the result to be
+// a string, which is the only type most rules care about.
+func (d *Detector) evalToString(src string) (string, error) {
+	result, err := d.EvalConfig.Eval(src)
+	if err != nil {
+		return "", err
+	}
+	if result.Type != hil.TypeString {
+		return "", fmt.Errorf("`%s` is not evaluated as string", src)
+	}
+	return result.Value.(string), nil
+}
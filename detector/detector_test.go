@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/hcl/hcl/ast"
 	"github.com/hashicorp/hcl/hcl/parser"
 	"github.com/hashicorp/hcl/hcl/token"
+	jsonparser "github.com/hashicorp/hcl/json/parser"
 	"github.com/wata727/tflint/config"
 	"github.com/wata727/tflint/evaluator"
 	"github.com/wata727/tflint/issue"
@@ -52,9 +53,8 @@ func TestDetect(t *testing.T) {
 		},
 	}
 
-	detectors = map[string]string{
-		"test_rule": "DetectMethodForTest",
-	}
+	registry = NewRegistry()
+	registry.Register(&testRule{})
 
 	for _, tc := range cases {
 		prev, _ := filepath.Abs(".")
@@ -91,7 +91,15 @@ module "ec2_instance" {
 	}
 }
 
-func (d *Detector) DetectMethodForTest(issues *[]*issue.Issue) {
+// testRule is a stand-in rule used only to exercise Detect()'s registry and
+// ignore-list handling.
+type testRule struct{}
+
+func (r *testRule) Name() string     { return "test_rule" }
+func (r *testRule) Severity() string { return "ERROR" }
+func (r *testRule) Link() string     { return "" }
+
+func (r *testRule) Detect(d *Detector, issues *[]*issue.Issue) {
 	*issues = append(*issues, &issue.Issue{
 		Type:    "TEST",
 		Message: "this is test method",
@@ -104,6 +112,7 @@ func TestHclLiteralToken(t *testing.T) {
 	type Input struct {
 		File string
 		Key  string
+		JSON bool
 	}
 
 	cases := []struct {
@@ -172,10 +181,36 @@ resource "aws_instance" "web" {
 			Result: token.Token{},
 			Error:  true,
 		},
+		{
+			Name: "return literal token from JSON source",
+			Input: Input{
+				File: `{
+    "resource": {
+        "aws_instance": {
+            "web": {
+                "instance_type": "t2.micro"
+            }
+        }
+    }
+}`,
+				Key:  "instance_type",
+				JSON: true,
+			},
+			Result: token.Token{
+				Text: "\"t2.micro\"",
+				JSON: true,
+			},
+			Error: false,
+		},
 	}
 
 	for _, tc := range cases {
-		root, _ := parser.Parse([]byte(tc.Input.File))
+		var root *ast.File
+		if tc.Input.JSON {
+			root, _ = jsonparser.Parse([]byte(tc.Input.File))
+		} else {
+			root, _ = parser.Parse([]byte(tc.Input.File))
+		}
 		list, _ := root.Node.(*ast.ObjectList)
 		item := list.Filter("resource", "aws_instance").Items[0]
 
@@ -199,6 +234,7 @@ func TestHclObjectItems(t *testing.T) {
 	type Input struct {
 		File string
 		Key  string
+		JSON bool
 	}
 
 	cases := []struct {
@@ -304,10 +340,43 @@ resource "aws_instance" "web" {
 			Result: []*ast.ObjectItem{},
 			Error:  true,
 		},
+		{
+			Name: "return object items from JSON source",
+			Input: Input{
+				File: `{
+    "resource": {
+        "aws_instance": {
+            "web": {
+                "root_block_device": {
+                    "volume_size": "16"
+                }
+            }
+        }
+    }
+}`,
+				Key:  "root_block_device",
+				JSON: true,
+			},
+			Result: []*ast.ObjectItem{
+				&ast.ObjectItem{
+					Keys: []*ast.ObjectKey{
+						&ast.ObjectKey{
+							Token: token.Token{Text: `"root_block_device"`, JSON: true},
+						},
+					},
+				},
+			},
+			Error: false,
+		},
 	}
 
 	for _, tc := range cases {
-		root, _ := parser.Parse([]byte(tc.Input.File))
+		var root *ast.File
+		if tc.Input.JSON {
+			root, _ = jsonparser.Parse([]byte(tc.Input.File))
+		} else {
+			root, _ = parser.Parse([]byte(tc.Input.File))
+		}
 		list, _ := root.Node.(*ast.ObjectList)
 		item := list.Filter("resource", "aws_instance").Items[0]
 
@@ -321,6 +390,22 @@ resource "aws_instance" "web" {
 			continue
 		}
 
+		// JSON source positions differ structurally from HCL's, so only
+		// compare the key text the JSON cases care about rather than the
+		// full position-laden AST the HCL cases check via reflect.DeepEqual.
+		if tc.Input.JSON {
+			if len(result) != len(tc.Result) {
+				t.Fatalf("Bad: %d items\nExpected: %d\n\ntestcase: %s", len(result), len(tc.Result), tc.Name)
+				continue
+			}
+			for i, item := range result {
+				if keyText(item.Keys[0]) != keyText(tc.Result[i].Keys[0]) {
+					t.Fatalf("Bad: %s\nExpected: %s\n\ntestcase: %s", keyText(item.Keys[0]), keyText(tc.Result[i].Keys[0]), tc.Name)
+				}
+			}
+			continue
+		}
+
 		if !reflect.DeepEqual(result, tc.Result) {
 			t.Fatalf("Bad: %s\nExpected: %s\n\ntestcase: %s", result, tc.Result, tc.Name)
 		}
@@ -331,6 +416,7 @@ func TestIsKeyNotFound(t *testing.T) {
 	type Input struct {
 		File string
 		Key  string
+		JSON bool
 	}
 
 	cases := []struct {
@@ -360,10 +446,32 @@ resource "aws_instance" "web" {
 			},
 			Result: true,
 		},
+		{
+			Name: "key found in JSON source",
+			Input: Input{
+				File: `{
+    "resource": {
+        "aws_instance": {
+            "web": {
+                "instance_type": "t2.micro"
+            }
+        }
+    }
+}`,
+				Key:  "instance_type",
+				JSON: true,
+			},
+			Result: false,
+		},
 	}
 
 	for _, tc := range cases {
-		root, _ := parser.Parse([]byte(tc.Input.File))
+		var root *ast.File
+		if tc.Input.JSON {
+			root, _ = jsonparser.Parse([]byte(tc.Input.File))
+		} else {
+			root, _ = parser.Parse([]byte(tc.Input.File))
+		}
 		list, _ := root.Node.(*ast.ObjectList)
 		item := list.Filter("resource", "aws_instance").Items[0]
 		result := IsKeyNotFound(item, tc.Input.Key)
@@ -374,6 +482,44 @@ resource "aws_instance" "web" {
 	}
 }
 
+func TestMakeModuleListMap(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Dir   string
+		Files []string
+	}{
+		{
+			Name:  "HCL module",
+			Dir:   "tf_aws_ec2_instance",
+			Files: []string{"main.tf"},
+		},
+		{
+			Name:  "JSON module",
+			Dir:   "tf_aws_ec2_instance_json",
+			Files: []string{"main.tf.json"},
+		},
+	}
+
+	prev, _ := filepath.Abs(".")
+	dir, _ := os.Getwd()
+	defer os.Chdir(prev)
+	os.Chdir(dir + "/test-fixtures")
+
+	for _, tc := range cases {
+		listMap, err := MakeModuleListMap(tc.Dir)
+		if err != nil {
+			t.Fatalf("should not be happen error.\nError: %s\n\ntestcase: %s", err, tc.Name)
+			continue
+		}
+
+		for _, f := range tc.Files {
+			if _, ok := listMap[f]; !ok {
+				t.Fatalf("Bad: `%s` is not loaded\n\ntestcase: %s", f, tc.Name)
+			}
+		}
+	}
+}
+
 func TestEvalToString(t *testing.T) {
 	type Input struct {
 		Src  string
@@ -0,0 +1,81 @@
+package detector
+
+import (
+	"fmt"
+	"plugin"
+
+	"github.com/wata727/tflint/config"
+	"github.com/wata727/tflint/issue"
+)
+
+// Rule is implemented by every detector rule, whether built into tflint or
+// supplied by a third-party rule plugin.
+type Rule interface {
+	Name() string
+	Detect(d *Detector, issues *[]*issue.Issue)
+	Severity() string
+	Link() string
+}
+
+// Registry holds every rule known to the running tflint process, keyed by
+// rule name so config overrides (enable/disable, severity) can look a rule
+// up by the same name users write in `.tflint.hcl`.
+type Registry struct {
+	rules map[string]Rule
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{rules: map[string]Rule{}}
+}
+
+// Register adds rule to the registry, keyed by its own name, replacing any
+// rule already registered under that name. The registry Detect runs is
+// unexported, so code outside this package adds rules to it only through
+// LoadPlugins.
+func (r *Registry) Register(rule Rule) {
+	r.rules[rule.Name()] = rule
+}
+
+// Rules returns every registered rule, keyed by name.
+func (r *Registry) Rules() map[string]Rule {
+	return r.rules
+}
+
+// LoadPlugins opens every external rule plugin referenced by a `plugin`
+// attribute in c's `rule` blocks and registers the Rule each one exports,
+// so a rule pack can ship as a compiled Go plugin instead of forking
+// tflint. A plugin is a `buildmode=plugin` shared object exporting a single
+// `Rule` symbol that satisfies the Rule interface.
+func (r *Registry) LoadPlugins(c *config.Config) error {
+	for name, rule := range c.Rules {
+		if rule.Plugin == "" {
+			continue
+		}
+
+		p, err := plugin.Open(rule.Plugin)
+		if err != nil {
+			return fmt.Errorf("failed to load plugin `%s`: %s", rule.Plugin, err)
+		}
+
+		sym, err := p.Lookup("Rule")
+		if err != nil {
+			return fmt.Errorf("plugin `%s` does not export `Rule`: %s", rule.Plugin, err)
+		}
+
+		pluginRule, ok := sym.(Rule)
+		if !ok {
+			return fmt.Errorf("plugin `%s`'s `Rule` does not implement detector.Rule", rule.Plugin)
+		}
+		if pluginRule.Name() != name {
+			return fmt.Errorf("plugin `%s`'s rule name `%s` does not match the `%s` block it was configured under", rule.Plugin, pluginRule.Name(), name)
+		}
+
+		r.Register(pluginRule)
+	}
+	return nil
+}
+
+// registry is the process-wide set of built-in and plugin rules. Individual
+// rule files register themselves here via init().
+var registry = NewRegistry()
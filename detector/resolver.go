@@ -0,0 +1,176 @@
+package detector
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	getter "github.com/hashicorp/go-getter"
+)
+
+// ModuleResolver turns a Terraform module `source` address (and optional
+// `version`) into a local directory containing that module's files. Local
+// paths are returned as-is; everything else (Git, HTTP archives, GitHub
+// shorthand, Terraform Registry addresses) is downloaded through go-getter
+// and cached under CacheDir, keyed by source+version so repeated runs don't
+// re-fetch and bumping version re-fetches instead of serving a stale cache.
+type ModuleResolver struct {
+	CacheDir string
+}
+
+// NewModuleResolver returns a ModuleResolver that caches downloads under
+// .tflint.d/modules relative to the current working directory.
+func NewModuleResolver() *ModuleResolver {
+	return &ModuleResolver{CacheDir: filepath.Join(".tflint.d", "modules")}
+}
+
+// moduleResolver is the resolver Detect() uses to turn `module` blocks'
+// source addresses into local directories.
+var moduleResolver = NewModuleResolver()
+
+// Resolve returns a local directory containing source's files at version
+// (the module block's `version` attribute, or "" if it has none), fetching
+// and caching it first when source isn't already a local path.
+func (r *ModuleResolver) Resolve(source, version string) (string, error) {
+	if isLocalSource(source) {
+		return source, nil
+	}
+
+	source = normalizeSource(source)
+	composite := compositeSource(source, version)
+	key := cacheKey(composite)
+	dest := filepath.Join(r.CacheDir, key)
+
+	if cached, err := r.cachedSource(key); err == nil && cached == composite {
+		return dest, nil
+	}
+
+	if err := os.MkdirAll(r.CacheDir, 0755); err != nil {
+		return "", err
+	}
+	if err := getter.Get(dest, withRef(source, version)); err != nil {
+		return "", fmt.Errorf("failed to fetch module `%s`: %s", composite, err)
+	}
+	if err := r.cacheSource(key, composite); err != nil {
+		return "", err
+	}
+
+	return dest, nil
+}
+
+// compositeSource combines source and version into the single string the
+// cache manifest is keyed and compared on, so bumping version invalidates
+// an already-cached entry instead of silently reusing it.
+func compositeSource(source, version string) string {
+	if version == "" {
+		return source
+	}
+	return source + "@" + version
+}
+
+// withRef appends version as a go-getter `ref` query parameter (the way
+// go-getter pins a Git/Mercurial checkout to a tag or branch), which is
+// also how a resolved Registry address pins to a release.
+func withRef(source, version string) string {
+	if version == "" {
+		return source
+	}
+	if strings.Contains(source, "?") {
+		return source + "&ref=" + version
+	}
+	return source + "?ref=" + version
+}
+
+func isLocalSource(source string) bool {
+	return strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../") || filepath.IsAbs(source)
+}
+
+// normalizeSource expands a bare Terraform Registry address
+// ("namespace/name/provider") to the GitHub repository the public registry
+// uses by convention (github.com/namespace/terraform-provider-name), so
+// go-getter's own github.com detector can take it from there.
+func normalizeSource(source string) string {
+	if !isRegistryAddress(source) {
+		return source
+	}
+
+	parts := strings.Split(source, "/")
+	namespace, name, provider := parts[0], parts[1], parts[2]
+	return fmt.Sprintf("github.com/%s/terraform-%s-%s", namespace, provider, name)
+}
+
+func isRegistryAddress(source string) bool {
+	if strings.Contains(source, "://") || strings.Contains(source, "::") {
+		return false
+	}
+
+	parts := strings.Split(source, "/")
+	if len(parts) != 3 {
+		return false
+	}
+
+	// A registry address's first segment is a bare namespace, never a host
+	// name, so reject e.g. "github.com/hashicorp/consul" (GitHub shorthand)
+	// before treating it as "namespace/name/provider".
+	return !strings.Contains(parts[0], ".")
+}
+
+func cacheKey(source string) string {
+	sum := sha256.Sum256([]byte(source))
+	return hex.EncodeToString(sum[:])
+}
+
+func (r *ModuleResolver) manifestPath() string {
+	return filepath.Join(r.CacheDir, "manifest.json")
+}
+
+func (r *ModuleResolver) manifest() (map[string]string, error) {
+	manifest := map[string]string{}
+
+	b, err := ioutil.ReadFile(r.manifestPath())
+	if os.IsNotExist(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func (r *ModuleResolver) cachedSource(key string) (string, error) {
+	manifest, err := r.manifest()
+	if err != nil {
+		return "", err
+	}
+	source, ok := manifest[key]
+	if !ok {
+		return "", fmt.Errorf("no cache entry for `%s`", key)
+	}
+	return source, nil
+}
+
+func (r *ModuleResolver) cacheSource(key, source string) error {
+	manifest, err := r.manifest()
+	if err != nil {
+		return err
+	}
+	manifest[key] = source
+
+	b, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(r.CacheDir, 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.manifestPath(), b, 0644)
+}
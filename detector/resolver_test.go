@@ -0,0 +1,109 @@
+package detector
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalizeSource(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Source string
+		Result string
+	}{
+		{
+			Name:   "registry address",
+			Source: "hashicorp/consul/aws",
+			Result: "github.com/hashicorp/terraform-aws-consul",
+		},
+		{
+			Name:   "git source is untouched",
+			Source: "git::https://example.com/vpc.git",
+			Result: "git::https://example.com/vpc.git",
+		},
+		{
+			Name:   "github shorthand is untouched",
+			Source: "github.com/hashicorp/consul",
+			Result: "github.com/hashicorp/consul",
+		},
+	}
+
+	for _, tc := range cases {
+		result := normalizeSource(tc.Source)
+		if result != tc.Result {
+			t.Fatalf("Bad: %s\nExpected: %s\n\ntestcase: %s", result, tc.Result, tc.Name)
+		}
+	}
+}
+
+func TestModuleResolverResolveGit(t *testing.T) {
+	remote, err := ioutil.TempDir("", "tflint-module-fixture")
+	if err != nil {
+		t.Fatalf("Failed to create fixture dir: %s", err)
+	}
+	defer os.RemoveAll(remote)
+
+	runGit(t, remote, "init")
+	if err := ioutil.WriteFile(filepath.Join(remote, "main.tf"), []byte(`
+resource "aws_instance" "web" {
+    instance_type = "t2.micro"
+}`), 0644); err != nil {
+		t.Fatalf("Failed to write fixture module: %s", err)
+	}
+	runGit(t, remote, "add", "main.tf")
+	runGit(t, remote, "-c", "user.email=test@example.com", "-c", "user.name=test", "commit", "-m", "fixture")
+
+	cacheDir, err := ioutil.TempDir("", "tflint-module-cache")
+	if err != nil {
+		t.Fatalf("Failed to create cache dir: %s", err)
+	}
+	defer os.RemoveAll(cacheDir)
+
+	resolver := &ModuleResolver{CacheDir: cacheDir}
+	dir, err := resolver.Resolve("git::file://"+remote, "")
+	if err != nil {
+		t.Fatalf("should not be happen error.\nError: %s", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "main.tf")); err != nil {
+		t.Fatalf("Bad: module was not fetched into `%s`: %s", dir, err)
+	}
+}
+
+func TestModuleResolverResolveVersionBustsCache(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "tflint-module-cache")
+	if err != nil {
+		t.Fatalf("Failed to create cache dir: %s", err)
+	}
+	defer os.RemoveAll(cacheDir)
+
+	resolver := &ModuleResolver{CacheDir: cacheDir}
+
+	key := cacheKey(compositeSource("hashicorp/consul/aws", "0.1.0"))
+	if err := resolver.cacheSource(key, compositeSource("hashicorp/consul/aws", "0.1.0")); err != nil {
+		t.Fatalf("Failed to seed cache: %s", err)
+	}
+
+	if cached, err := resolver.cachedSource(key); err != nil || cached != "hashicorp/consul/aws@0.1.0" {
+		t.Fatalf("Bad: cache entry for version 0.1.0 was not found as seeded")
+	}
+
+	bumpedKey := cacheKey(compositeSource("hashicorp/consul/aws", "0.2.0"))
+	if bumpedKey == key {
+		t.Fatalf("Bad: bumping version did not change the cache key")
+	}
+	if _, err := resolver.cachedSource(bumpedKey); err == nil {
+		t.Fatalf("Bad: a cache entry already exists for the bumped version")
+	}
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %s\n%s", args, err, out)
+	}
+}
@@ -0,0 +1,41 @@
+package evaluator
+
+import (
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/hashicorp/hil"
+	hilast "github.com/hashicorp/hil/ast"
+	"github.com/wata727/tflint/config"
+)
+
+// Evaluator resolves HIL interpolations (`${...}`) against the variables
+// declared across a parsed Terraform template.
+type Evaluator struct {
+	hilEvalConfig *hil.EvalConfig
+}
+
+// NewEvaluator builds an Evaluator from the variables declared in listMap.
+func NewEvaluator(listMap map[string]*ast.ObjectList, c *config.Config) (*Evaluator, error) {
+	varMap, err := detectVariables(listMap, c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Evaluator{
+		hilEvalConfig: &hil.EvalConfig{
+			GlobalScope: &hilast.BasicScope{
+				VarMap: varMap,
+			},
+		},
+	}, nil
+}
+
+// Eval evaluates a HIL source string (e.g. "${var.foo}") against the
+// evaluator's scope.
+func (e *Evaluator) Eval(src string) (hil.EvaluationResult, error) {
+	root, err := hil.Parse(src)
+	if err != nil {
+		return hil.EvaluationResult{}, err
+	}
+
+	return hil.Eval(root, e.hilEvalConfig)
+}
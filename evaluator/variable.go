@@ -1,11 +1,17 @@
 package evaluator
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/hcl"
 	hclast "github.com/hashicorp/hcl/hcl/ast"
 	hilast "github.com/hashicorp/hil/ast"
+	"github.com/wata727/tflint/config"
 )
 
 type hclVariable struct {
@@ -16,7 +22,7 @@ type hclVariable struct {
 	Fields       []string `hcl:",decodedFields"`
 }
 
-func detectVariables(listMap map[string]*hclast.ObjectList) (map[string]hilast.Variable, error) {
+func detectVariables(listMap map[string]*hclast.ObjectList, c *config.Config) (map[string]hilast.Variable, error) {
 	varMap := make(map[string]hilast.Variable)
 
 	for _, list := range listMap {
@@ -29,94 +35,228 @@ func detectVariables(listMap map[string]*hclast.ObjectList) (map[string]hilast.V
 			if v.Default == nil {
 				continue
 			}
-			varName := "var." + v.Name
-			varMap[varName] = parseVariable(v.Default, v.DeclaredType)
+			varMap["var."+v.Name] = parseVariable(v.Default, v.DeclaredType)
 		}
 	}
 
+	overrides, err := loadVariableOverrides(c)
+	if err != nil {
+		return nil, err
+	}
+	for name, val := range overrides {
+		declaredType := ""
+		if v, ok := varMap["var."+name]; ok {
+			declaredType = declaredTypeOf(v)
+		}
+		varMap["var."+name] = parseVariable(val, declaredType)
+	}
+
 	return varMap, nil
 }
 
+// declaredTypeOf infers the `type` string that would have produced v, so
+// that an override value can be coerced the same way the declared default
+// was.
+func declaredTypeOf(v hilast.Variable) string {
+	switch v.Type {
+	case hilast.TypeBool:
+		return "bool"
+	case hilast.TypeInt, hilast.TypeFloat:
+		return "number"
+	case hilast.TypeList:
+		return "list"
+	case hilast.TypeMap:
+		return "map"
+	default:
+		return "string"
+	}
+}
+
+// loadVariableOverrides collects variable overrides in increasing order of
+// precedence, mirroring Terraform itself: TF_VAR_* environment variables,
+// then -var-file, then -var.
+func loadVariableOverrides(c *config.Config) (map[string]interface{}, error) {
+	overrides := map[string]interface{}{}
+
+	for name, val := range envVars() {
+		overrides[name] = val
+	}
+
+	if c == nil {
+		return overrides, nil
+	}
+
+	for _, file := range c.VarFiles {
+		vars, err := parseVarFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for name, val := range vars {
+			overrides[name] = val
+		}
+	}
+
+	for name, val := range c.Vars {
+		overrides[name] = val
+	}
+
+	return overrides, nil
+}
+
+func envVars() map[string]string {
+	vars := map[string]string{}
+	for _, env := range os.Environ() {
+		if !strings.HasPrefix(env, "TF_VAR_") {
+			continue
+		}
+		pair := strings.SplitN(strings.TrimPrefix(env, "TF_VAR_"), "=", 2)
+		if len(pair) == 2 {
+			vars[pair[0]] = pair[1]
+		}
+	}
+	return vars
+}
+
+func parseVarFile(path string) (map[string]interface{}, error) {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var vars map[string]interface{}
+	if err := hcl.Unmarshal(src, &vars); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
+
+// parseVariable converts an HCL-decoded default value into a HIL variable,
+// honoring the `type` declared on the `variable` block where possible and
+// falling back to inferring the type from val's Go representation.
 func parseVariable(val interface{}, varType string) hilast.Variable {
-	// varType is overwrite invariably. Because, happen panic when used in incorrect type
+	switch declaredKind(varType) {
+	case "bool":
+		return parseBool(val)
+	case "number":
+		return parseNumber(val)
+	case "list":
+		return parseList(val)
+	case "map":
+		return parseMap(val)
+	case "string":
+		return hilast.Variable{Type: hilast.TypeString, Value: toString(val)}
+	default:
+		return inferVariable(val)
+	}
+}
+
+// declaredKind strips a parameterized type such as "list(string)" down to
+// its bare kind ("list"), since Terraform 0.11-era `variable` blocks also
+// accept the unparameterized form.
+func declaredKind(varType string) string {
+	if idx := strings.Index(varType, "("); idx != -1 {
+		return varType[:idx]
+	}
+	return varType
+}
+
+// inferVariable is the fallback used when no declared type applies: it
+// guesses bool/number/list/map/string from val's own Go type, the same way
+// Terraform itself infers a variable's type from its default when no
+// `type` attribute is given.
+func inferVariable(val interface{}) hilast.Variable {
 	switch reflect.TypeOf(val).Kind() {
-	case reflect.String:
-		varType = "string"
+	case reflect.Bool:
+		return parseBool(val)
+	case reflect.Int, reflect.Float64:
+		return parseNumber(val)
 	case reflect.Slice:
-		varType = "list"
+		return parseList(val)
 	case reflect.Map:
-		varType = "map"
+		return parseMap(val)
 	default:
-		varType = "string"
+		return hilast.Variable{Type: hilast.TypeString, Value: toString(val)}
 	}
+}
 
-	var hilVar hilast.Variable
-	switch varType {
-	case "string":
-		hilVar = hilast.Variable{
-			Type:  hilast.TypeString,
-			Value: val,
+func toString(val interface{}) string {
+	if s, ok := val.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", val)
+}
+
+func parseBool(val interface{}) hilast.Variable {
+	switch v := val.(type) {
+	case bool:
+		return hilast.Variable{Type: hilast.TypeBool, Value: v}
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return hilast.Variable{Type: hilast.TypeBool, Value: b}
 		}
-	case "map":
-		// When HCL map var convert(parse) to Go var,
-		// get map in slice. following example:
-		//
-		// ```HCL
-		// key = {
-		//     name = "test"
-		//     value = "hcl"
-		// }
-		// ```
-		//
-		// Incorrect:
-		//
-		// map[string]string{
-		//     "key": map[string][string]{
-		//         "name":  "test",
-		//         "value": "hcl",
-		//     },
-		// }
-		//
-		// Correct:
-		//
-		// []map[string]string{
-		//     map[string]string{
-		//         "name":  "test",
-		//         "value": "hcl",
-		//     },
-		// }
-		//
-		fallthrough
-	case "list":
-		s := reflect.ValueOf(val)
-
-		switch reflect.TypeOf(s.Index(0).Interface()).Kind() {
-		case reflect.Map:
-			var variables map[string]hilast.Variable
-			variables = map[string]hilast.Variable{}
-			for i := 0; i < s.Len(); i++ {
-				ms := reflect.ValueOf(s.Index(i).Interface())
-				for _, k := range ms.MapKeys() {
-					key := k.Interface().(string)
-					value := ms.MapIndex(reflect.ValueOf(key)).Interface()
-					variables[key] = parseVariable(value, "")
-				}
-			}
-			hilVar = hilast.Variable{
-				Type:  hilast.TypeMap,
-				Value: variables,
-			}
-		default:
-			var variables []hilast.Variable
-			for i := 0; i < s.Len(); i++ {
-				variables = append(variables, parseVariable(s.Index(i).Interface(), ""))
-			}
-			hilVar = hilast.Variable{
-				Type:  hilast.TypeList,
-				Value: variables,
-			}
+	}
+	return hilast.Variable{Type: hilast.TypeUnknown, Value: val}
+}
+
+func parseNumber(val interface{}) hilast.Variable {
+	switch v := val.(type) {
+	case int:
+		return hilast.Variable{Type: hilast.TypeInt, Value: v}
+	case float64:
+		if i, err := strconv.Atoi(strconv.FormatFloat(v, 'f', -1, 64)); err == nil {
+			return hilast.Variable{Type: hilast.TypeInt, Value: i}
+		}
+		return hilast.Variable{Type: hilast.TypeFloat, Value: v}
+	case string:
+		if i, err := strconv.Atoi(v); err == nil {
+			return hilast.Variable{Type: hilast.TypeInt, Value: i}
+		}
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return hilast.Variable{Type: hilast.TypeFloat, Value: f}
 		}
 	}
+	return hilast.Variable{Type: hilast.TypeUnknown, Value: val}
+}
+
+// parseList converts a slice default into a HIL list, recursing into each
+// element so heterogeneous nested structures (e.g. a list of maps) keep
+// their own inferred types instead of collapsing to string.
+//
+// HCL decodes a `key = { ... }` block nested in a list as a Go slice
+// containing a single map, so that shape is handled the same way here.
+func parseList(val interface{}) hilast.Variable {
+	s := reflect.ValueOf(val)
+	if s.Len() == 0 {
+		return hilast.Variable{Type: hilast.TypeList, Value: []hilast.Variable{}}
+	}
 
-	return hilVar
+	if reflect.TypeOf(s.Index(0).Interface()).Kind() == reflect.Map {
+		return parseMap(val)
+	}
+
+	variables := make([]hilast.Variable, 0, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		variables = append(variables, parseVariable(s.Index(i).Interface(), ""))
+	}
+	return hilast.Variable{Type: hilast.TypeList, Value: variables}
+}
+
+// parseMap converts a map default into a HIL map. Like parseList, it also
+// accepts the single-element-slice-of-map shape HCL produces for a bare
+// `key = { ... }` block.
+func parseMap(val interface{}) hilast.Variable {
+	s := reflect.ValueOf(val)
+	if s.Kind() == reflect.Slice {
+		if s.Len() == 0 {
+			return hilast.Variable{Type: hilast.TypeMap, Value: map[string]hilast.Variable{}}
+		}
+		s = reflect.ValueOf(s.Index(0).Interface())
+	}
+
+	variables := map[string]hilast.Variable{}
+	for _, k := range s.MapKeys() {
+		key := k.Interface().(string)
+		variables[key] = parseVariable(s.MapIndex(k).Interface(), "")
+	}
+	return hilast.Variable{Type: hilast.TypeMap, Value: variables}
 }
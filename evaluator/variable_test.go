@@ -0,0 +1,86 @@
+package evaluator
+
+import (
+	"reflect"
+	"testing"
+
+	hilast "github.com/hashicorp/hil/ast"
+)
+
+func TestParseVariable(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Val     interface{}
+		VarType string
+		Result  hilast.Variable
+	}{
+		{
+			Name:    "untyped bool default",
+			Val:     true,
+			VarType: "",
+			Result:  hilast.Variable{Type: hilast.TypeBool, Value: true},
+		},
+		{
+			Name:    "untyped int default",
+			Val:     1,
+			VarType: "",
+			Result:  hilast.Variable{Type: hilast.TypeInt, Value: 1},
+		},
+		{
+			Name:    "declared bool type",
+			Val:     "true",
+			VarType: "bool",
+			Result:  hilast.Variable{Type: hilast.TypeBool, Value: true},
+		},
+		{
+			Name:    "declared number type",
+			Val:     "1",
+			VarType: "number",
+			Result:  hilast.Variable{Type: hilast.TypeInt, Value: 1},
+		},
+		{
+			Name:    "declared string type coerces a bool default",
+			Val:     true,
+			VarType: "string",
+			Result:  hilast.Variable{Type: hilast.TypeString, Value: "true"},
+		},
+	}
+
+	for _, tc := range cases {
+		result := parseVariable(tc.Val, tc.VarType)
+		if !reflect.DeepEqual(result, tc.Result) {
+			t.Fatalf("Bad: %#v\nExpected: %#v\n\ntestcase: %s", result, tc.Result, tc.Name)
+		}
+	}
+}
+
+func TestToString(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Val    interface{}
+		Result string
+	}{
+		{
+			Name:   "string",
+			Val:    "t2.micro",
+			Result: "t2.micro",
+		},
+		{
+			Name:   "bool",
+			Val:    true,
+			Result: "true",
+		},
+		{
+			Name:   "int",
+			Val:    1,
+			Result: "1",
+		},
+	}
+
+	for _, tc := range cases {
+		result := toString(tc.Val)
+		if result != tc.Result {
+			t.Fatalf("Bad: %s\nExpected: %s\n\ntestcase: %s", result, tc.Result, tc.Name)
+		}
+	}
+}
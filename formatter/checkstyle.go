@@ -0,0 +1,65 @@
+package formatter
+
+import (
+	"encoding/xml"
+
+	"github.com/wata727/tflint/issue"
+)
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Message  string `xml:"message,attr"`
+	Severity string `xml:"severity,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleResult struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+// CheckstyleFormatter renders issues as Checkstyle XML, grouped by file, so
+// CI systems that already understand Checkstyle reports can ingest tflint
+// output without a tflint-specific plugin.
+type CheckstyleFormatter struct{}
+
+// Format implements Formatter.
+func (f *CheckstyleFormatter) Format(issues []*issue.Issue) (string, error) {
+	byFile := map[string][]checkstyleError{}
+	var order []string
+	for _, i := range issues {
+		if _, ok := byFile[i.File]; !ok {
+			order = append(order, i.File)
+		}
+		byFile[i.File] = append(byFile[i.File], checkstyleError{
+			Line:     i.Line,
+			Message:  i.Message,
+			Severity: severityOrDefault(i.Severity),
+			Source:   i.Type,
+		})
+	}
+
+	result := checkstyleResult{Version: "1.0"}
+	for _, file := range order {
+		result.Files = append(result.Files, checkstyleFile{Name: file, Errors: byFile[file]})
+	}
+
+	b, err := xml.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(b), nil
+}
+
+func severityOrDefault(severity string) string {
+	if severity == "" {
+		return "error"
+	}
+	return severity
+}
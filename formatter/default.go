@@ -0,0 +1,21 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wata727/tflint/issue"
+)
+
+// DefaultFormatter renders issues the way a compiler prints warnings: one
+// line per issue, file and line first.
+type DefaultFormatter struct{}
+
+// Format implements Formatter.
+func (f *DefaultFormatter) Format(issues []*issue.Issue) (string, error) {
+	lines := make([]string, 0, len(issues))
+	for _, i := range issues {
+		lines = append(lines, fmt.Sprintf("%s:%d: [%s] %s (%s)", i.File, i.Line, strings.ToUpper(i.Severity), i.Message, i.Type))
+	}
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,23 @@
+package formatter
+
+import "github.com/wata727/tflint/issue"
+
+// Formatter renders a set of issues as a single string to print to stdout.
+type Formatter interface {
+	Format(issues []*issue.Issue) (string, error)
+}
+
+// New returns the Formatter registered for name, falling back to the
+// human-readable default formatter for an empty or unrecognized name.
+func New(name string) Formatter {
+	switch name {
+	case "json":
+		return &JSONFormatter{}
+	case "checkstyle":
+		return &CheckstyleFormatter{}
+	case "sarif":
+		return &SarifFormatter{}
+	default:
+		return &DefaultFormatter{}
+	}
+}
@@ -0,0 +1,82 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/wata727/tflint/issue"
+)
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Format string
+		Result Formatter
+	}{
+		{Name: "default", Format: "", Result: &DefaultFormatter{}},
+		{Name: "json", Format: "json", Result: &JSONFormatter{}},
+		{Name: "checkstyle", Format: "checkstyle", Result: &CheckstyleFormatter{}},
+		{Name: "sarif", Format: "sarif", Result: &SarifFormatter{}},
+		{Name: "unknown falls back to default", Format: "yaml", Result: &DefaultFormatter{}},
+	}
+
+	for _, tc := range cases {
+		result := New(tc.Format)
+		if fmt.Sprintf("%T", result) != fmt.Sprintf("%T", tc.Result) {
+			t.Fatalf("Bad: %T\nExpected: %T\n\ntestcase: %s", result, tc.Result, tc.Name)
+		}
+	}
+}
+
+func testIssues() []*issue.Issue {
+	return []*issue.Issue{
+		{
+			Type:     "aws_instance_invalid_type",
+			Message:  "\"t1.2xlarge\" is an invalid instance type",
+			Line:     5,
+			File:     "main.tf",
+			Severity: "error",
+			Link:     "https://github.com/wata727/tflint/blob/master/docs/rules/aws_instance_invalid_type.md",
+		},
+	}
+}
+
+func TestJSONFormatterFormat(t *testing.T) {
+	result, err := (&JSONFormatter{}).Format(testIssues())
+	if err != nil {
+		t.Fatalf("should not be happen error.\nError: %s", err)
+	}
+
+	for _, want := range []string{`"file":"main.tf"`, `"line":5`, `"rule":"aws_instance_invalid_type"`, `"severity":"error"`} {
+		if !strings.Contains(result, want) {
+			t.Fatalf("Bad: %s\nExpected to contain: %s", result, want)
+		}
+	}
+}
+
+func TestCheckstyleFormatterFormat(t *testing.T) {
+	result, err := (&CheckstyleFormatter{}).Format(testIssues())
+	if err != nil {
+		t.Fatalf("should not be happen error.\nError: %s", err)
+	}
+
+	for _, want := range []string{`<checkstyle`, `name="main.tf"`, `severity="error"`} {
+		if !strings.Contains(result, want) {
+			t.Fatalf("Bad: %s\nExpected to contain: %s", result, want)
+		}
+	}
+}
+
+func TestSarifFormatterFormat(t *testing.T) {
+	result, err := (&SarifFormatter{}).Format(testIssues())
+	if err != nil {
+		t.Fatalf("should not be happen error.\nError: %s", err)
+	}
+
+	for _, want := range []string{`"version": "2.1.0"`, `"ruleId": "aws_instance_invalid_type"`, `"level": "error"`} {
+		if !strings.Contains(result, want) {
+			t.Fatalf("Bad: %s\nExpected to contain: %s", result, want)
+		}
+	}
+}
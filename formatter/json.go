@@ -0,0 +1,41 @@
+package formatter
+
+import (
+	"encoding/json"
+
+	"github.com/wata727/tflint/issue"
+)
+
+// jsonIssue is the stable `--format json` schema.
+type jsonIssue struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Link     string `json:"link"`
+}
+
+// JSONFormatter renders issues as a single JSON array.
+type JSONFormatter struct{}
+
+// Format implements Formatter.
+func (f *JSONFormatter) Format(issues []*issue.Issue) (string, error) {
+	out := make([]jsonIssue, 0, len(issues))
+	for _, i := range issues {
+		out = append(out, jsonIssue{
+			File:     i.File,
+			Line:     i.Line,
+			Rule:     i.Type,
+			Severity: i.Severity,
+			Message:  i.Message,
+			Link:     i.Link,
+		})
+	}
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,122 @@
+package formatter
+
+import (
+	"encoding/json"
+
+	"github.com/wata727/tflint/issue"
+)
+
+const sarifSchema = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+const sarifVersion = "2.1.0"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	ID      string `json:"id"`
+	HelpURI string `json:"helpUri,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// SarifFormatter renders issues as a SARIF 2.1.0 log, the format GitHub code
+// scanning and most CI dashboards expect.
+type SarifFormatter struct{}
+
+// Format implements Formatter.
+func (f *SarifFormatter) Format(issues []*issue.Issue) (string, error) {
+	seen := map[string]bool{}
+	rules := []sarifRule{}
+	results := []sarifResult{}
+
+	for _, i := range issues {
+		if !seen[i.Type] {
+			seen[i.Type] = true
+			rules = append(rules, sarifRule{ID: i.Type, HelpURI: i.Link})
+		}
+		results = append(results, sarifResult{
+			RuleID:  i.Type,
+			Level:   sarifLevel(i.Severity),
+			Message: sarifMessage{Text: i.Message},
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: i.File},
+						Region:           sarifRegion{StartLine: i.Line},
+					},
+				},
+			},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool:    sarifTool{Driver: sarifDriver{Name: "tflint", Rules: rules}},
+				Results: results,
+			},
+		},
+	}
+
+	b, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func sarifLevel(severity string) string {
+	switch severity {
+	case "error", "ERROR":
+		return "error"
+	case "warning", "WARNING":
+		return "warning"
+	default:
+		return "note"
+	}
+}
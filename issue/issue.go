@@ -0,0 +1,11 @@
+package issue
+
+// Issue represents a single detected problem in a Terraform template.
+type Issue struct {
+	Type     string
+	Message  string
+	Line     int
+	File     string
+	Severity string
+	Link     string
+}
@@ -0,0 +1,29 @@
+package logger
+
+import (
+	"log"
+	"os"
+)
+
+// Logger wraps the standard logger and carries the debug flag given to
+// Init. None of its methods read Debug.
+type Logger struct {
+	Debug bool
+	*log.Logger
+}
+
+// Init returns a Logger that writes to stderr.
+func Init(debug bool) *Logger {
+	return &Logger{
+		Debug:  debug,
+		Logger: log.New(os.Stderr, "", log.LstdFlags),
+	}
+}
+
+// Error logs err when it is non-nil.
+func (l *Logger) Error(err error) {
+	if err == nil {
+		return
+	}
+	l.Printf("[ERROR] %s", err)
+}
@@ -0,0 +1,87 @@
+// Package watch turns tflint from a batch tool into one that can run
+// continuously alongside an editor or pre-commit hook.
+package watch
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/wata727/tflint/detector"
+	"github.com/wata727/tflint/formatter"
+)
+
+// Watcher re-parses only the *.tf / *.tf.json files that change under Dir,
+// re-runs every rule of d over its whole ListMap, and streams the resulting
+// issues to Out in f's format.
+type Watcher struct {
+	Detector  *detector.Detector
+	Formatter formatter.Formatter
+	Out       io.Writer
+	Dir       string
+}
+
+// New returns a Watcher for d, rendering results with f and writing them to
+// out. An empty dir watches the current working directory.
+func New(d *detector.Detector, f formatter.Formatter, out io.Writer, dir string) *Watcher {
+	if dir == "" {
+		dir = "."
+	}
+	return &Watcher{Detector: d, Formatter: f, Out: out, Dir: dir}
+}
+
+// Run watches w.Dir until stop is closed, re-running w.Detector.DetectFiles
+// on every changed Terraform file and writing the formatted issues to
+// w.Out. The other files already in the detector's ListMap stay loaded
+// across changes; modules are resolved and parsed again on every run. Run
+// blocks until stop is closed or an
+// unrecoverable watch error occurs.
+func (w *Watcher) Run(stop <-chan struct{}) error {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer fsw.Close()
+
+	if err := fsw.Add(w.Dir); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case event, ok := <-fsw.Events:
+			if !ok {
+				return nil
+			}
+			if !isTerraformFile(event.Name) || event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			w.detect(event.Name)
+		case err, ok := <-fsw.Errors:
+			if !ok {
+				return nil
+			}
+			return err
+		case <-stop:
+			return nil
+		}
+	}
+}
+
+// detect re-parses path, runs Detect again, and streams the formatted
+// result to w.Out, logging (rather than failing the watch loop) on error.
+func (w *Watcher) detect(path string) {
+	issues := w.Detector.DetectFiles([]string{path})
+
+	out, err := w.Formatter.Format(issues)
+	if err != nil {
+		fmt.Fprintf(w.Out, "[ERROR] %s\n", err)
+		return
+	}
+	fmt.Fprintln(w.Out, out)
+}
+
+func isTerraformFile(name string) bool {
+	return strings.HasSuffix(name, ".tf") || strings.HasSuffix(name, ".tf.json")
+}
@@ -0,0 +1,72 @@
+package watch
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/wata727/tflint/config"
+	"github.com/wata727/tflint/detector"
+	"github.com/wata727/tflint/evaluator"
+	"github.com/wata727/tflint/formatter"
+	"github.com/wata727/tflint/logger"
+)
+
+func TestWatcherRunDetectsChangedFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tflint-watch-fixture")
+	if err != nil {
+		t.Fatalf("Failed to create fixture dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tf := filepath.Join(dir, "main.tf")
+	if err := ioutil.WriteFile(tf, []byte(`
+resource "aws_instance" "web" {
+    instance_type = "t1.micro"
+}`), 0644); err != nil {
+		t.Fatalf("Failed to write fixture file: %s", err)
+	}
+
+	c := config.Init()
+	evalConfig, err := evaluator.NewEvaluator(map[string]*ast.ObjectList{}, c)
+	if err != nil {
+		t.Fatalf("Failed to build evaluator: %s", err)
+	}
+	d := &detector.Detector{
+		ListMap:    map[string]*ast.ObjectList{},
+		Config:     c,
+		EvalConfig: evalConfig,
+		Logger:     logger.Init(false),
+	}
+
+	var out bytes.Buffer
+	w := New(d, formatter.New("json"), &out, dir)
+
+	stop := make(chan struct{})
+	done := make(chan error, 1)
+	go func() { done <- w.Run(stop) }()
+
+	// Give fsnotify time to register the watch before triggering a change.
+	time.Sleep(100 * time.Millisecond)
+	if err := ioutil.WriteFile(tf, []byte(`
+resource "aws_instance" "web" {
+    instance_type = "t2.micro"
+}`), 0644); err != nil {
+		t.Fatalf("Failed to rewrite fixture file: %s", err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	close(stop)
+
+	if err := <-done; err != nil {
+		t.Fatalf("should not be happen error.\nError: %s", err)
+	}
+
+	if out.Len() == 0 {
+		t.Fatal("Bad: watcher did not emit any formatted output for the changed file")
+	}
+}